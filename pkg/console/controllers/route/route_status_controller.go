@@ -0,0 +1,153 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	routev1listers "github.com/openshift/client-go/route/listers/route/v1"
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+
+	"github.com/openshift/console-operator/pkg/api"
+	subresourceroute "github.com/openshift/console-operator/pkg/console/subresource/route"
+)
+
+const (
+	controllerName = "RouteStatusController"
+
+	routeStatusControllerAvailable   = "RouteStatusControllerAvailable"
+	routeStatusControllerDegraded    = "RouteStatusControllerDegraded"
+	routeStatusControllerProgressing = "RouteStatusControllerProgressing"
+)
+
+// StatusController watches the console Route's admission status and
+// propagates it to the Console operator status as Available/Degraded/
+// Progressing conditions. It is split out of the main sync loop so that
+// creating the route and waiting for the router to admit it can be tested
+// and reasoned about independently, mirroring the equivalent split in
+// cluster-authentication-operator.
+type StatusController struct {
+	operatorClient v1helpers.OperatorClient
+	routeLister    routev1listers.RouteLister
+
+	lastStateMu sync.Mutex
+	lastState   string
+}
+
+func NewRouteStatusController(
+	operatorClient v1helpers.OperatorClient,
+	routeInformer factory.Informer,
+	routeLister routev1listers.RouteLister,
+	recorder events.Recorder,
+) factory.Controller {
+	c := &StatusController{
+		operatorClient: operatorClient,
+		routeLister:    routeLister,
+	}
+	return factory.New().
+		WithSync(c.sync).
+		WithInformers(routeInformer).
+		ToController(controllerName, recorder)
+}
+
+func (c *StatusController) sync(ctx context.Context, controllerContext factory.SyncContext) error {
+	route, err := c.routeLister.Routes(api.TargetNamespace).Get(api.OpenShiftConsoleRouteName)
+	if apierrors.IsNotFound(err) {
+		return c.updateStatus(ctx, false, true, false, "RouteNotFound", "console route does not exist yet")
+	}
+	if err != nil {
+		// a lister error that isn't NotFound means we can't tell the real
+		// admission state at all; that's a genuine failure, not "still
+		// progressing", so report it as Degraded in addition to returning the
+		// error for the controller to retry.
+		if statusErr := c.updateStatus(ctx, false, false, true, "RouteStatusError", fmt.Sprintf("failed to get console route: %v", err)); statusErr != nil {
+			return statusErr
+		}
+		return err
+	}
+
+	if !subresourceroute.IsAdmitted(route) {
+		return c.updateStatus(ctx, false, true, false, "RouteNotAdmitted", fmt.Sprintf("console route %q has not been admitted by the default ingress controller", route.Name))
+	}
+
+	host := subresourceroute.GetCanonicalHost(route)
+	if len(host) == 0 {
+		return c.updateStatus(ctx, false, true, false, "RouteHostNotReady", fmt.Sprintf("console route %q is admitted but reports no canonical host yet", route.Name))
+	}
+
+	return c.updateStatus(ctx, true, false, false, "AsExpected", fmt.Sprintf("console route %q is admitted, host: %s", route.Name, host))
+}
+
+// GetCanonicalHost returns the externally reachable host for the named Route,
+// reading from the shared informer cache rather than re-fetching the Route
+// from the API server on every call.
+func (c *StatusController) GetCanonicalHost(namespace, name string) (string, error) {
+	route, err := c.routeLister.Routes(namespace).Get(name)
+	if err != nil {
+		return "", err
+	}
+	return subresourceroute.GetCanonicalHost(route), nil
+}
+
+func (c *StatusController) updateStatus(ctx context.Context, available, progressing, degraded bool, reason, message string) error {
+	state := fmt.Sprintf("available=%v progressing=%v degraded=%v reason=%s", available, progressing, degraded, reason)
+	c.lastStateMu.Lock()
+	unchanged := c.lastState == state
+	c.lastStateMu.Unlock()
+	if unchanged {
+		// only push a status update when the admission state actually changed,
+		// instead of writing the same conditions on every resync.
+		return nil
+	}
+
+	availableCondition := operatorv1.OperatorCondition{
+		Type:    routeStatusControllerAvailable,
+		Status:  operatorv1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}
+	if available {
+		availableCondition.Status = operatorv1.ConditionTrue
+	}
+
+	degradedCondition := operatorv1.OperatorCondition{
+		Type:    routeStatusControllerDegraded,
+		Status:  operatorv1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}
+	if degraded {
+		degradedCondition.Status = operatorv1.ConditionTrue
+	}
+
+	progressingCondition := operatorv1.OperatorCondition{
+		Type:    routeStatusControllerProgressing,
+		Status:  operatorv1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}
+	if progressing {
+		progressingCondition.Status = operatorv1.ConditionTrue
+	}
+
+	_, _, err := v1helpers.UpdateStatus(ctx, c.operatorClient,
+		v1helpers.UpdateConditionFn(availableCondition),
+		v1helpers.UpdateConditionFn(degradedCondition),
+		v1helpers.UpdateConditionFn(progressingCondition),
+	)
+	if err != nil {
+		// leave lastState alone on failure so the next sync retries the write
+		// instead of treating it as a no-op forever.
+		return err
+	}
+
+	c.lastStateMu.Lock()
+	c.lastState = state
+	c.lastStateMu.Unlock()
+	return nil
+}
@@ -0,0 +1,333 @@
+package routeingress
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	networkingv1listers "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/klog"
+
+	routev1 "github.com/openshift/api/route/v1"
+	routeclient "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1"
+	routev1listers "github.com/openshift/client-go/route/listers/route/v1"
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"github.com/openshift/library-go/pkg/operator/events"
+
+	"github.com/openshift/console-operator/pkg/console/subresource/route"
+)
+
+const (
+	controllerName = "IngressToRouteController"
+
+	// ingressToRouteAnnotation, when set to "true" on an Ingress, opts it into
+	// being reconciled into one or more console-managed Routes.
+	ingressToRouteAnnotation = "console.openshift.io/ingress-to-route"
+
+	// terminationPolicyAnnotation overrides the default (edge) TLS termination
+	// used for routes generated from the annotated ingress.
+	terminationPolicyAnnotation = "console.openshift.io/termination-policy"
+
+	// legacyIngressClassAnnotation is the deprecated way of selecting an
+	// ingress controller, superseded by spec.ingressClassName.
+	legacyIngressClassAnnotation = "kubernetes.io/ingress.class"
+
+	// legacyIngressClassName is the value of legacyIngressClassAnnotation that
+	// opts an Ingress into ingress-to-route reconciliation when
+	// spec.ingressClassName is unset.
+	legacyIngressClassName = "console-ingress-to-route"
+
+	// ingressClassControllerName is the spec.controller an IngressClass must
+	// carry for us to reconcile Ingresses that reference it.
+	ingressClassControllerName = "openshift.io/console-ingress-to-route"
+
+	ingressGroupVersion = "networking.k8s.io/v1"
+	ingressKind         = "Ingress"
+)
+
+// IngressToRouteController watches Ingress objects opted into console
+// management and materializes equivalent Routes for them, so that admins who
+// standardize on the Ingress API still have a supported path to the console.
+type IngressToRouteController struct {
+	routeClient        routeclient.RoutesGetter
+	routeLister        routev1listers.RouteLister
+	ingressLister      networkingv1listers.IngressLister
+	ingressClassLister networkingv1listers.IngressClassLister
+	secretLister       corev1listers.SecretLister
+}
+
+func NewIngressToRouteController(
+	routeClient routeclient.RoutesGetter,
+	routeInformer factory.Informer,
+	routeLister routev1listers.RouteLister,
+	ingressInformer factory.Informer,
+	ingressLister networkingv1listers.IngressLister,
+	ingressClassInformer factory.Informer,
+	ingressClassLister networkingv1listers.IngressClassLister,
+	secretInformer factory.Informer,
+	secretLister corev1listers.SecretLister,
+	recorder events.Recorder,
+) factory.Controller {
+	c := &IngressToRouteController{
+		routeClient:        routeClient,
+		routeLister:        routeLister,
+		ingressLister:      ingressLister,
+		ingressClassLister: ingressClassLister,
+		secretLister:       secretLister,
+	}
+	return factory.New().
+		WithSync(c.sync).
+		WithInformers(routeInformer, ingressInformer, ingressClassInformer, secretInformer).
+		ToController(controllerName, recorder)
+}
+
+func (c *IngressToRouteController) sync(ctx context.Context, controllerContext factory.SyncContext) error {
+	ingresses, err := c.ingressLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, ingress := range ingresses {
+		if !c.shouldReconcile(ingress) {
+			continue
+		}
+		if err := c.reconcileIngress(ctx, controllerContext.Recorder(), ingress); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile ingress %s/%s: %w", ingress.Namespace, ingress.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// shouldReconcile reports whether an Ingress has opted into console
+// ingress-to-route reconciliation and targets the console's IngressClass.
+func (c *IngressToRouteController) shouldReconcile(ingress *networkingv1.Ingress) bool {
+	if ingress.Annotations[ingressToRouteAnnotation] != "true" {
+		return false
+	}
+	return c.hasConsoleIngressClass(ingress)
+}
+
+func (c *IngressToRouteController) hasConsoleIngressClass(ingress *networkingv1.Ingress) bool {
+	if ingress.Spec.IngressClassName != nil {
+		ingressClass, err := c.ingressClassLister.Get(*ingress.Spec.IngressClassName)
+		if apierrors.IsNotFound(err) {
+			klog.V(4).Infof("ingress %s/%s references unknown ingressClassName %q", ingress.Namespace, ingress.Name, *ingress.Spec.IngressClassName)
+			return false
+		}
+		if err != nil {
+			klog.V(4).Infof("failed to look up ingressClassName %q for ingress %s/%s: %v", *ingress.Spec.IngressClassName, ingress.Namespace, ingress.Name, err)
+			return false
+		}
+		return ingressClass.Spec.Controller == ingressClassControllerName
+	}
+
+	// fall back to the legacy annotation when spec.ingressClassName is unset
+	return ingress.Annotations[legacyIngressClassAnnotation] == legacyIngressClassName
+}
+
+func (c *IngressToRouteController) reconcileIngress(ctx context.Context, recorder events.Recorder, ingress *networkingv1.Ingress) error {
+	desired, err := c.desiredRoutes(ingress)
+	if err != nil {
+		return err
+	}
+
+	existing, err := c.existingRoutes(ingress)
+	if err != nil {
+		return err
+	}
+
+	desiredNames := map[string]bool{}
+	for _, r := range desired {
+		desiredNames[r.Name] = true
+		if _, _, err := route.ApplyRoute(c.routeClient, recorder, r); err != nil {
+			return err
+		}
+	}
+
+	// garbage-collect routes for rules that were removed from the ingress
+	for _, r := range existing {
+		if desiredNames[r.Name] {
+			continue
+		}
+		if err := c.routeClient.Routes(r.Namespace).Delete(ctx, r.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// existingRoutes returns the routes previously created by this controller for
+// the given ingress, identified by owner reference.
+func (c *IngressToRouteController) existingRoutes(ingress *networkingv1.Ingress) ([]*routev1.Route, error) {
+	routes, err := c.routeLister.Routes(ingress.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var owned []*routev1.Route
+	for _, r := range routes {
+		if ownerIndex(r.OwnerReferences, ingress.UID) >= 0 {
+			owned = append(owned, r)
+		}
+	}
+	return owned, nil
+}
+
+// desiredRoutes builds one Route per ingress rule host/path combination.
+func (c *IngressToRouteController) desiredRoutes(ingress *networkingv1.Ingress) ([]*routev1.Route, error) {
+	termination, err := terminationFor(ingress)
+	if err != nil {
+		return nil, err
+	}
+
+	var routes []*routev1.Route
+	for ruleIdx, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for pathIdx, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+
+			tlsConfig, err := c.tlsFor(ingress, rule.Host, termination)
+			if err != nil {
+				return nil, err
+			}
+
+			name := fmt.Sprintf("%s-%d-%d", ingress.Name, ruleIdx, pathIdx)
+			r := &routev1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: ingress.Namespace,
+				},
+				Spec: routev1.RouteSpec{
+					Host: rule.Host,
+					Path: path.Path,
+					To: routev1.RouteTargetReference{
+						Kind: "Service",
+						Name: path.Backend.Service.Name,
+					},
+					TLS: tlsConfig,
+				},
+			}
+
+			// seed the desired object with whatever owner references the route
+			// already carries, so setOwnerRef can tell a stale (e.g. old Ingress
+			// API version) owner reference apart from a brand-new route and
+			// migrate it in place instead of only ever appending.
+			if existing, err := c.routeLister.Routes(ingress.Namespace).Get(name); err == nil {
+				r.OwnerReferences = existing.OwnerReferences
+			} else if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+
+			setOwnerRef(r, ingress)
+			routes = append(routes, r)
+		}
+	}
+	return routes, nil
+}
+
+func terminationFor(ingress *networkingv1.Ingress) (routev1.TLSTerminationType, error) {
+	switch policy := ingress.Annotations[terminationPolicyAnnotation]; policy {
+	case "", string(routev1.TLSTerminationEdge):
+		return routev1.TLSTerminationEdge, nil
+	case string(routev1.TLSTerminationReencrypt):
+		return routev1.TLSTerminationReencrypt, nil
+	case string(routev1.TLSTerminationPassthrough):
+		return routev1.TLSTerminationPassthrough, nil
+	default:
+		return "", fmt.Errorf("invalid %s annotation value %q", terminationPolicyAnnotation, policy)
+	}
+}
+
+// tlsFor resolves the TLS secret referenced for host (if any) into a
+// routev1.TLSConfig. When the Ingress requests edge/reencrypt termination but
+// no spec.tls entry matches host, it still returns a TLSConfig with that
+// termination and no Certificate/Key so the route terminates TLS using the router's default
+// certificate, rather than silently downgrading the route to plain HTTP.
+func (c *IngressToRouteController) tlsFor(ingress *networkingv1.Ingress, host string, termination routev1.TLSTerminationType) (*routev1.TLSConfig, error) {
+	if termination == routev1.TLSTerminationPassthrough {
+		return &routev1.TLSConfig{Termination: termination}, nil
+	}
+
+	for _, tlsEntry := range ingress.Spec.TLS {
+		if tlsEntry.SecretName == "" || !hostMatches(tlsEntry.Hosts, host) {
+			continue
+		}
+
+		secret, err := c.secretLister.Secrets(ingress.Namespace).Get(tlsEntry.SecretName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TLS secret %s/%s: %w", ingress.Namespace, tlsEntry.SecretName, err)
+		}
+
+		return &routev1.TLSConfig{
+			Termination:                   termination,
+			InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+			Certificate:                   string(secret.Data[corev1TLSCertKey]),
+			Key:                           string(secret.Data[corev1TLSKeyKey]),
+		}, nil
+	}
+
+	return &routev1.TLSConfig{
+		Termination:                   termination,
+		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+	}, nil
+}
+
+const (
+	corev1TLSCertKey = "tls.crt"
+	corev1TLSKeyKey  = "tls.key"
+)
+
+func hostMatches(hosts []string, host string) bool {
+	if len(hosts) == 0 {
+		// per the Ingress API, an empty Hosts list applies to the default/wildcard host
+		return true
+	}
+	for _, h := range hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
+
+func setOwnerRef(r *routev1.Route, ingress *networkingv1.Ingress) {
+	owner := metav1.OwnerReference{
+		APIVersion: ingressGroupVersion,
+		Kind:       ingressKind,
+		Name:       ingress.Name,
+		UID:        ingress.UID,
+		Controller: boolPtr(true),
+	}
+
+	if idx := ownerIndex(r.OwnerReferences, ingress.UID); idx >= 0 {
+		// migrate routes that were stamped by an older Ingress API version
+		r.OwnerReferences[idx] = owner
+		return
+	}
+	r.OwnerReferences = append(r.OwnerReferences, owner)
+}
+
+func ownerIndex(refs []metav1.OwnerReference, uid types.UID) int {
+	for i, ref := range refs {
+		if ref.UID == uid {
+			return i
+		}
+	}
+	return -1
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
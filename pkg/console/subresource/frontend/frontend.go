@@ -0,0 +1,284 @@
+// Package frontend generalizes the console's "front door" subresource beyond
+// OpenShift Route objects. On OpenShift the sync loop keeps using the route
+// subresource package directly; on vanilla Kubernetes distributions that do
+// not ship the Route CRD, this package produces an equivalent
+// networking.k8s.io/v1 Ingress instead, so the operator can be reused outside
+// OpenShift. A Gateway API HTTPRoute backend is left as a follow-up once a
+// cluster-admin-facing API for selecting it exists.
+package frontend
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	networkingv1client "k8s.io/client-go/kubernetes/typed/networking/v1"
+	"k8s.io/klog"
+
+	routev1 "github.com/openshift/api/route/v1"
+	routeclient "github.com/openshift/client-go/route/clientset/versioned/typed/route/v1"
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourcemerge"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/openshift/console-operator/pkg/api"
+	"github.com/openshift/console-operator/pkg/console/subresource/route"
+	"github.com/openshift/console-operator/pkg/console/subresource/util"
+)
+
+// Backend identifies which API the operator uses to expose the console.
+type Backend string
+
+const (
+	// BackendRoute manages routev1.Route objects, the OpenShift default.
+	BackendRoute Backend = "Route"
+	// BackendIngress manages networking.k8s.io/v1 Ingress objects, for
+	// vanilla Kubernetes distributions that lack the Route CRD.
+	BackendIngress Backend = "Ingress"
+)
+
+// DetectBackend probes the API server for the route.openshift.io API group
+// to decide which backend the operator should use. Clusters that serve the
+// Route API get BackendRoute; everything else falls back to BackendIngress.
+func DetectBackend(discoveryClient discovery.DiscoveryInterface) (Backend, error) {
+	apiGroups, err := discoveryClient.ServerGroups()
+	if err != nil {
+		return "", err
+	}
+	for _, group := range apiGroups.Groups {
+		if group.Name == routev1.GroupName {
+			return BackendRoute, nil
+		}
+	}
+	klog.V(2).Infof("route.openshift.io API group not found, falling back to Ingress backend")
+	return BackendIngress, nil
+}
+
+// DefaultIngress is the Ingress-backend equivalent of route.DefaultRoute: it
+// points at the console service directly, or at the redirect service when a
+// custom hostname is configured.
+func DefaultIngress(cr *operatorv1.Console) *networkingv1.Ingress {
+	serviceName := api.OpenShiftConsoleServiceName
+	if route.IsCustomRouteSet(cr) {
+		serviceName = api.OpenshiftConsoleRedirectServiceName
+	}
+	return ingressFor(cr, "", "", serviceName, "", nil)
+}
+
+// CustomIngress is the Ingress-backend equivalent of route.CustomRoute, for
+// the legacy single custom hostname/secret fields.
+func CustomIngress(cr *operatorv1.Console, tlsConfig *route.CustomTLSCert) *networkingv1.Ingress {
+	return ingressFor(cr, api.OpenshiftConsoleCustomRouteName, cr.Spec.Route.Hostname, api.OpenShiftConsoleServiceName, cr.Spec.Route.Secret.Name, tlsConfig)
+}
+
+// CustomIngresses is the Ingress-backend equivalent of route.CustomRoutes: it
+// builds one Ingress per entry in cr.Spec.Route.Routes, so the multi-hostname
+// custom route API works the same way on both backends. tlsConfigs is keyed
+// by hostname, matching route.CustomRoutes.
+func CustomIngresses(cr *operatorv1.Console, tlsConfigs map[string]*route.CustomTLSCert) []*networkingv1.Ingress {
+	if !route.IsCustomRoutesSet(cr) {
+		return nil
+	}
+
+	ingresses := make([]*networkingv1.Ingress, 0, len(cr.Spec.Route.Routes))
+	for _, customRoute := range cr.Spec.Route.Routes {
+		ingresses = append(ingresses, ingressFor(
+			cr,
+			route.CustomRouteName(customRoute.Hostname),
+			customRoute.Hostname,
+			api.OpenShiftConsoleServiceName,
+			customRoute.Secret.Name,
+			tlsConfigs[customRoute.Hostname],
+		))
+	}
+	return ingresses
+}
+
+// ingressFor builds the shared Ingress shape for the default and custom
+// frontends. name overrides the default SharedMeta() name when non-empty.
+func ingressFor(cr *operatorv1.Console, name, host, serviceName, secretName string, tlsConfig *route.CustomTLSCert) *networkingv1.Ingress {
+	pathType := networkingv1.PathTypeImplementationSpecific
+	portName := api.ConsoleContainerPortName
+	if serviceName == api.OpenshiftConsoleRedirectServiceName {
+		portName = api.RedirectContainerPortName
+	}
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: util.SharedMeta(),
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: serviceName,
+											// the port names match what route.go's
+											// DefaultRoute uses as the Route's TargetPort
+											// for each service: api.ConsoleContainerPortName
+											// for the console service and
+											// api.RedirectContainerPortName for the redirect
+											// service; an Ingress backend Port.Name must
+											// match the Service's port name.
+											Port: networkingv1.ServiceBackendPort{
+												Name: portName,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if len(name) != 0 {
+		ingress.ObjectMeta.Name = name
+	}
+
+	if tlsConfig != nil {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{
+			{
+				Hosts:      []string{host},
+				SecretName: secretName,
+			},
+		}
+	}
+
+	util.AddOwnerRef(ingress, util.OwnerRefFrom(cr))
+	return ingress
+}
+
+// ApplyIngress mirrors route.ApplyRoute: it creates the Ingress if it does
+// not exist, and updates it in place when the desired spec or metadata has
+// drifted.
+func ApplyIngress(client networkingv1client.IngressesGetter, recorder events.Recorder, required *networkingv1.Ingress) (*networkingv1.Ingress, bool, error) {
+	existing, err := client.Ingresses(required.Namespace).Get(context.TODO(), required.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		requiredCopy := required.DeepCopy()
+		actual, err := client.Ingresses(requiredCopy.Namespace).Create(context.TODO(), resourcemerge.WithCleanLabelsAndAnnotations(requiredCopy).(*networkingv1.Ingress), metav1.CreateOptions{})
+		return actual, true, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	existingCopy := existing.DeepCopy()
+	modified := resourcemerge.BoolPtr(false)
+	resourcemerge.EnsureObjectMeta(modified, &existingCopy.ObjectMeta, required.ObjectMeta)
+	specSame := equality.Semantic.DeepEqual(existingCopy.Spec, required.Spec)
+
+	if specSame && !*modified {
+		klog.V(4).Infof("%s ingress exists and is in the correct state", existingCopy.ObjectMeta.Name)
+		return existingCopy, false, nil
+	}
+
+	existingCopy.Spec = required.Spec
+	actual, err := client.Ingresses(required.Namespace).Update(context.TODO(), existingCopy, metav1.UpdateOptions{})
+	return actual, true, err
+}
+
+// Reconciler applies the console's default frontend object using whichever
+// backend this cluster supports. The backend is detected once, at
+// construction time, via DetectBackend, so the operator's startup code picks
+// Route vs. Ingress exactly once instead of re-probing discovery on every
+// sync.
+type Reconciler struct {
+	backend       Backend
+	routeClient   routeclient.RoutesGetter
+	ingressClient networkingv1client.IngressesGetter
+	recorder      events.Recorder
+}
+
+// NewReconciler probes discoveryClient for the Route API and returns a
+// Reconciler bound to whichever backend the cluster supports.
+func NewReconciler(discoveryClient discovery.DiscoveryInterface, routeClient routeclient.RoutesGetter, ingressClient networkingv1client.IngressesGetter, recorder events.Recorder) (*Reconciler, error) {
+	backend, err := DetectBackend(discoveryClient)
+	if err != nil {
+		return nil, err
+	}
+	return &Reconciler{
+		backend:       backend,
+		routeClient:   routeClient,
+		ingressClient: ingressClient,
+		recorder:      recorder,
+	}, nil
+}
+
+// Backend reports which backend this Reconciler was detected to use.
+func (r *Reconciler) Backend() Backend {
+	return r.backend
+}
+
+// ApplyDefault reconciles the console's primary frontend object for cr: a
+// Route on OpenShift, or an Ingress everywhere else.
+func (r *Reconciler) ApplyDefault(cr *operatorv1.Console) error {
+	switch r.backend {
+	case BackendRoute:
+		required, err := route.DefaultRoute(cr)
+		if err != nil {
+			return err
+		}
+		_, _, err = route.ApplyRoute(r.routeClient, r.recorder, required)
+		return err
+	case BackendIngress:
+		_, _, err := ApplyIngress(r.ingressClient, r.recorder, DefaultIngress(cr))
+		return err
+	default:
+		return fmt.Errorf("unknown console frontend backend %q", r.backend)
+	}
+}
+
+// ApplyCustom reconciles the console's custom-hostname frontend objects for
+// cr: the legacy single Hostname/Secret pair and the multi-hostname Routes
+// list, both using whichever backend this Reconciler was detected to use.
+// tlsConfig backs the legacy single hostname; tlsConfigs is keyed by hostname
+// and backs the multi-hostname list, matching route.CustomRoute/CustomRoutes.
+func (r *Reconciler) ApplyCustom(cr *operatorv1.Console, tlsConfig *route.CustomTLSCert, tlsConfigs map[string]*route.CustomTLSCert) error {
+	switch r.backend {
+	case BackendRoute:
+		if route.IsCustomRouteSet(cr) && len(cr.Spec.Route.Hostname) != 0 {
+			required, err := route.CustomRoute(cr, tlsConfig)
+			if err != nil {
+				return err
+			}
+			if _, _, err := route.ApplyRoute(r.routeClient, r.recorder, required); err != nil {
+				return err
+			}
+		}
+		required, err := route.CustomRoutes(cr, tlsConfigs)
+		if err != nil {
+			return err
+		}
+		for _, rt := range required {
+			if _, _, err := route.ApplyRoute(r.routeClient, r.recorder, rt); err != nil {
+				return err
+			}
+		}
+		return nil
+	case BackendIngress:
+		if route.IsCustomRouteSet(cr) && len(cr.Spec.Route.Hostname) != 0 {
+			if _, _, err := ApplyIngress(r.ingressClient, r.recorder, CustomIngress(cr, tlsConfig)); err != nil {
+				return err
+			}
+		}
+		for _, required := range CustomIngresses(cr, tlsConfigs) {
+			if _, _, err := ApplyIngress(r.ingressClient, r.recorder, required); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown console frontend backend %q", r.backend)
+	}
+}
@@ -2,6 +2,8 @@ package route
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	// kube
 	corev1 "k8s.io/api/core/v1"
@@ -9,6 +11,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -49,12 +52,18 @@ func ApplyRoute(client routeclient.RoutesGetter, recorder events.Recorder, requi
 	resourcemerge.EnsureObjectMeta(modified, &existingCopy.ObjectMeta, required.ObjectMeta)
 	specSame := equality.Semantic.DeepEqual(existingCopy.Spec, required.Spec)
 
-	if specSame && !*modified {
+	// EnsureObjectMeta only reconciles labels/annotations; owner references
+	// (e.g. migrating a route from an older Ingress API version's owner ref)
+	// have to be reconciled explicitly.
+	ownerRefsSame := equality.Semantic.DeepEqual(existingCopy.OwnerReferences, required.OwnerReferences)
+
+	if specSame && ownerRefsSame && !*modified {
 		klog.V(4).Infof("%s route exists and is in the correct state", existingCopy.ObjectMeta.Name)
 		return existingCopy, false, nil
 	}
 
 	existingCopy.Spec = required.Spec
+	existingCopy.OwnerReferences = required.OwnerReferences
 	actual, err := client.Routes(required.Namespace).Update(context.TODO(), existingCopy, metav1.UpdateOptions{})
 	return actual, true, err
 }
@@ -80,24 +89,37 @@ func GetOrCreate(ctx context.Context, client routeclient.RoutesGetter, required
 // If custom hostname for the console is set, then the default route
 // should point to the redirect `console-redirect` service and the
 // created custom route should be pointing to the `console` service.
-func DefaultRoute(cr *operatorv1.Console) *routev1.Route {
+func DefaultRoute(cr *operatorv1.Console) (*routev1.Route, error) {
+	if err := ValidateRouteConfig(cr); err != nil {
+		return nil, err
+	}
+
 	route := DefaultStub()
 	usePort := api.ConsoleContainerPortName
 	tlsTermination := routev1.TLSTerminationReencrypt
 	serviceName := api.OpenShiftConsoleServiceName
+	insecurePolicy := insecureEdgeTerminationPolicy(cr)
 	if IsCustomRouteSet(cr) {
+		// the default route always points at the redirect service over edge
+		// termination with an HTTP->HTTPS redirect in this configuration;
+		// Spec.Route.TLS applies to the default route only when there is no
+		// custom route, since passthrough/reencrypt or a disabled redirect
+		// here would break the redirect.
 		usePort = api.RedirectContainerPortName
 		tlsTermination = routev1.TLSTerminationEdge
 		serviceName = api.OpenshiftConsoleRedirectServiceName
+		insecurePolicy = routev1.InsecureEdgeTerminationPolicyRedirect
+	} else if override := cr.Spec.Route.TLS.Termination; len(override) != 0 {
+		tlsTermination = override
 	}
 	route.Spec = routev1.RouteSpec{
 		To:             toService(serviceName),
 		Port:           port(usePort),
-		TLS:            tls(nil, tlsTermination),
+		TLS:            tls(nil, tlsTermination, insecurePolicy),
 		WildcardPolicy: wildcard(),
 	}
 	util.AddOwnerRef(route, util.OwnerRefFrom(cr))
-	return route
+	return route, nil
 }
 
 func DefaultStub() *routev1.Route {
@@ -107,18 +129,120 @@ func DefaultStub() *routev1.Route {
 	}
 }
 
-func CustomRoute(cr *operatorv1.Console, tlsConfig *CustomTLSCert) *routev1.Route {
+func CustomRoute(cr *operatorv1.Console, tlsConfig *CustomTLSCert) (*routev1.Route, error) {
+	if err := ValidateRouteConfig(cr); err != nil {
+		return nil, err
+	}
+
 	route := DefaultStub()
 	route.ObjectMeta.Name = api.OpenshiftConsoleCustomRouteName
 	route.Spec = routev1.RouteSpec{
 		Host:           cr.Spec.Route.Hostname,
 		To:             toService(api.OpenShiftConsoleServiceName),
 		Port:           port(api.ConsoleContainerPortName),
-		TLS:            tls(tlsConfig, routev1.TLSTerminationReencrypt),
+		TLS:            tls(tlsConfig, terminationPolicy(cr, routev1.TLSTerminationReencrypt), insecureEdgeTerminationPolicy(cr)),
 		WildcardPolicy: wildcard(),
 	}
 	util.AddOwnerRef(route, util.OwnerRefFrom(cr))
-	return route
+	return route, nil
+}
+
+// terminationPolicy returns the cluster-wide TLS termination override from
+// cr.Spec.Route.TLS, falling back to def when it is unset.
+func terminationPolicy(cr *operatorv1.Console, def routev1.TLSTerminationType) routev1.TLSTerminationType {
+	if cr != nil && len(cr.Spec.Route.TLS.Termination) != 0 {
+		return cr.Spec.Route.TLS.Termination
+	}
+	return def
+}
+
+// insecureEdgeTerminationPolicy returns the configured HTTP->HTTPS redirect
+// behavior, defaulting to Redirect to preserve the operator's historical
+// behavior when the field is unset.
+func insecureEdgeTerminationPolicy(cr *operatorv1.Console) routev1.InsecureEdgeTerminationPolicyType {
+	if cr != nil && len(cr.Spec.Route.TLS.InsecureEdgeTerminationPolicy) != 0 {
+		return cr.Spec.Route.TLS.InsecureEdgeTerminationPolicy
+	}
+	return routev1.InsecureEdgeTerminationPolicyRedirect
+}
+
+// ValidateRouteConfig rejects TLS configurations the sync controller should
+// refuse to apply. Passthrough routes are not terminated by the router, so a
+// custom certificate/key can never take effect for them; callers should
+// surface the returned error as a degraded condition rather than silently
+// dropping the certificate.
+func ValidateRouteConfig(cr *operatorv1.Console) error {
+	if cr == nil {
+		return nil
+	}
+	if terminationPolicy(cr, "") == routev1.TLSTerminationPassthrough && len(cr.Spec.Route.Secret.Name) != 0 {
+		return fmt.Errorf("route TLS termination %q cannot be combined with a custom certificate/key secret", routev1.TLSTerminationPassthrough)
+	}
+	for _, customRoute := range cr.Spec.Route.Routes {
+		if customTermination(cr, customRoute) == routev1.TLSTerminationPassthrough && len(customRoute.Secret.Name) != 0 {
+			return fmt.Errorf("route %q: TLS termination %q cannot be combined with a custom certificate/key secret", customRoute.Hostname, routev1.TLSTerminationPassthrough)
+		}
+	}
+	return nil
+}
+
+// CustomRoutes builds one Route per entry in cr.Spec.Route.Routes, letting
+// clusters expose the console under several vanity hostnames, each with its
+// own optional TLS secret and termination type. tlsConfigs is keyed by
+// hostname; entries with no corresponding tlsConfig are created without a
+// custom certificate/key (the router's default certificate is used instead).
+func CustomRoutes(cr *operatorv1.Console, tlsConfigs map[string]*CustomTLSCert) ([]*routev1.Route, error) {
+	if err := ValidateRouteConfig(cr); err != nil {
+		return nil, err
+	}
+	if !IsCustomRoutesSet(cr) {
+		return nil, nil
+	}
+
+	routes := make([]*routev1.Route, 0, len(cr.Spec.Route.Routes))
+	for _, customRoute := range cr.Spec.Route.Routes {
+		route := DefaultStub()
+		route.ObjectMeta.Name = CustomRouteName(customRoute.Hostname)
+		route.Spec = routev1.RouteSpec{
+			Host:           customRoute.Hostname,
+			To:             toService(api.OpenShiftConsoleServiceName),
+			Port:           port(api.ConsoleContainerPortName),
+			TLS:            tls(tlsConfigs[customRoute.Hostname], customTermination(cr, customRoute), insecureEdgeTerminationPolicy(cr)),
+			WildcardPolicy: wildcard(),
+		}
+		util.AddOwnerRef(route, util.OwnerRefFrom(cr))
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// CustomRouteName derives the Route object name for one of the multiple
+// custom hostnames, so the same hostname always maps to the same Route and
+// the sync loop can tell which Routes are still desired.
+func CustomRouteName(hostname string) string {
+	return fmt.Sprintf("%s-%s", api.OpenshiftConsoleCustomRouteName, strings.ToLower(hostname))
+}
+
+// DesiredCustomRouteNames returns the Route object names that should exist
+// for the currently configured custom hostnames. The sync loop diffs this
+// against the Routes it owns and deletes anything left over from a hostname
+// that was removed from the spec.
+func DesiredCustomRouteNames(cr *operatorv1.Console) sets.String {
+	names := sets.NewString()
+	for _, customRoute := range cr.Spec.Route.Routes {
+		names.Insert(CustomRouteName(customRoute.Hostname))
+	}
+	return names
+}
+
+// customTermination resolves the effective termination for a single custom
+// hostname: a per-route override takes precedence, then the cluster-wide
+// Spec.Route.TLS.Termination, then the reencrypt default.
+func customTermination(cr *operatorv1.Console, customRoute operatorv1.CustomRouteSpec) routev1.TLSTerminationType {
+	if len(customRoute.Termination) != 0 {
+		return customRoute.Termination
+	}
+	return terminationPolicy(cr, routev1.TLSTerminationReencrypt)
 }
 
 func toService(serviceName string) routev1.RouteTargetReference {
@@ -136,12 +260,15 @@ func port(port string) *routev1.RoutePort {
 	}
 }
 
-func tls(tlsConfig *CustomTLSCert, terminationType routev1.TLSTerminationType) *routev1.TLSConfig {
+func tls(tlsConfig *CustomTLSCert, terminationType routev1.TLSTerminationType, insecureEdgeTerminationPolicy routev1.InsecureEdgeTerminationPolicyType) *routev1.TLSConfig {
 	tls := &routev1.TLSConfig{
 		Termination:                   terminationType,
-		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+		InsecureEdgeTerminationPolicy: insecureEdgeTerminationPolicy,
 	}
-	if tlsConfig != nil {
+	// passthrough routes aren't terminated by the router, so a certificate/key
+	// here would never be used; ValidateRouteConfig rejects this combination
+	// before it reaches here, but we still avoid setting bogus fields.
+	if tlsConfig != nil && terminationType != routev1.TLSTerminationPassthrough {
 		tls.Certificate = tlsConfig.Certificate
 		tls.Key = tlsConfig.Key
 	}
@@ -196,7 +323,16 @@ func IsCustomRouteSet(operatorConfig *operatorv1.Console) bool {
 	if operatorConfig == nil {
 		return false
 	}
-	return len(operatorConfig.Spec.Route.Hostname) != 0
+	return len(operatorConfig.Spec.Route.Hostname) != 0 || IsCustomRoutesSet(operatorConfig)
+}
+
+// IsCustomRoutesSet reports whether the multi-hostname Routes field is
+// populated, independent of the legacy single-hostname field.
+func IsCustomRoutesSet(operatorConfig *operatorv1.Console) bool {
+	if operatorConfig == nil {
+		return false
+	}
+	return len(operatorConfig.Spec.Route.Routes) != 0
 }
 
 // Check if reference for secret holding custom TLS certificate and key is set
@@ -204,5 +340,13 @@ func IsCustomRouteSecretSet(operatorConfig *operatorv1.Console) bool {
 	if operatorConfig == nil {
 		return false
 	}
-	return len(operatorConfig.Spec.Route.Secret.Name) != 0
+	if len(operatorConfig.Spec.Route.Secret.Name) != 0 {
+		return true
+	}
+	for _, customRoute := range operatorConfig.Spec.Route.Routes {
+		if len(customRoute.Secret.Name) != 0 {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,19 @@
+package v1
+
+// ConditionStatus mirrors corev1.ConditionStatus for operator conditions.
+type ConditionStatus string
+
+const (
+	ConditionTrue    ConditionStatus = "True"
+	ConditionFalse   ConditionStatus = "False"
+	ConditionUnknown ConditionStatus = "Unknown"
+)
+
+// OperatorCondition is a single condition reported on an operator's status,
+// e.g. Available, Degraded, Progressing.
+type OperatorCondition struct {
+	Type    string          `json:"type"`
+	Status  ConditionStatus `json:"status"`
+	Reason  string          `json:"reason,omitempty"`
+	Message string          `json:"message,omitempty"`
+}
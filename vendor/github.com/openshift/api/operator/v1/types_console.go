@@ -0,0 +1,87 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	routev1 "github.com/openshift/api/route/v1"
+)
+
+// Console provides a means to configure an operator to manage the console.
+type Console struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ConsoleSpec   `json:"spec"`
+	Status ConsoleStatus `json:"status"`
+}
+
+type ConsoleSpec struct {
+	// route contains hostname and secret reference that enables custom routes for console.
+	Route ConsoleConfigRoute `json:"route,omitempty"`
+}
+
+type ConsoleStatus struct {
+}
+
+// ConsoleConfigRoute holds information on external route access to console.
+type ConsoleConfigRoute struct {
+	// hostname is the desired custom domain under which console will be available.
+	Hostname string `json:"hostname,omitempty"`
+
+	// secret points to secret in the openshift-config namespace that contains the
+	// certificate and key to be used by the default ingress controller for
+	// Hostname. The secret needs to contain two keys: tls.crt and tls.key.
+	Secret SecretNameReference `json:"secret,omitempty"`
+
+	// routes is a list of additional vanity hostnames the console should be
+	// exposed under, each with its own optional TLS secret and termination
+	// override. This is additive to the legacy single Hostname/Secret pair
+	// above, for clusters that need the console reachable under several
+	// domains with distinct certificates.
+	// +optional
+	Routes []CustomRouteSpec `json:"routes,omitempty"`
+
+	// tls configures the TLS termination and HTTP->HTTPS redirect behavior
+	// used for console routes. While a custom hostname is set, the default
+	// route ignores it and always uses edge termination with Redirect. Unset
+	// fields keep the operator's historical defaults.
+	// +optional
+	TLS RouteTLSConfig `json:"tls,omitempty"`
+}
+
+// CustomRouteSpec describes one of several additional hostnames the console
+// can be reached under, each carrying its own TLS material and, optionally,
+// its own termination.
+type CustomRouteSpec struct {
+	// hostname is the vanity domain for this route, e.g. "console.example.com".
+	Hostname string `json:"hostname"`
+
+	// secret, when set, names the Secret (in the console-operator's namespace)
+	// holding the tls.crt/tls.key for this hostname.
+	// +optional
+	Secret SecretNameReference `json:"secret,omitempty"`
+
+	// termination overrides the cluster-wide Spec.Route.TLS.Termination for
+	// this hostname only. Defaults to the cluster-wide value when unset.
+	// +optional
+	Termination routev1.TLSTerminationType `json:"termination,omitempty"`
+}
+
+// RouteTLSConfig configures how console Routes terminate TLS.
+type RouteTLSConfig struct {
+	// termination is one of edge, reencrypt, or passthrough. Defaults to
+	// reencrypt (edge for the default route's redirect-service configuration)
+	// when unset.
+	// +optional
+	Termination routev1.TLSTerminationType `json:"termination,omitempty"`
+
+	// insecureEdgeTerminationPolicy is one of None, Allow, or Redirect.
+	// Defaults to Redirect when unset.
+	// +optional
+	InsecureEdgeTerminationPolicy routev1.InsecureEdgeTerminationPolicyType `json:"insecureEdgeTerminationPolicy,omitempty"`
+}
+
+// SecretNameReference references a Secret by name in the same namespace.
+type SecretNameReference struct {
+	Name string `json:"name,omitempty"`
+}